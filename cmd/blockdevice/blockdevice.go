@@ -0,0 +1,90 @@
+/*
+Copyright 2020-2021 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blockdevice
+
+import (
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/openebs/openebsctl/pkg/blockdevice"
+	"github.com/openebs/openebsctl/pkg/completion"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// matchesOneOf reports whether value case-insensitively equals one of valid,
+// treating an empty value (flag not set) as always matching.
+func matchesOneOf(value string, valid []string) bool {
+	if value == "" {
+		return true
+	}
+	for _, v := range valid {
+		if strings.EqualFold(value, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewCmdGetBlockDevice displays Node-wise BlockDevices, with dynamic
+// completion of BlockDevice names from the cluster.
+func NewCmdGetBlockDevice(openebsNS *string) *cobra.Command {
+	var (
+		outputFormat string
+		node         string
+		claimState   string
+		state        string
+		minSize      string
+	)
+	cmd := &cobra.Command{
+		Use:     "blockdevice [name ...]",
+		Aliases: []string{"bd", "blockdevices"},
+		Short:   "Displays Node wise BlockDevices",
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return completion.BlockDeviceNames(*openebsNS)(cmd, args, toComplete)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !matchesOneOf(claimState, blockdevice.ValidClaimStates) {
+				return errors.Errorf("invalid --claim-state value %q, must be one of %v", claimState, blockdevice.ValidClaimStates)
+			}
+			if !matchesOneOf(state, blockdevice.ValidStates) {
+				return errors.Errorf("invalid --state value %q, must be one of %v", state, blockdevice.ValidStates)
+			}
+			var minSizeBytes uint64
+			if minSize != "" {
+				var err error
+				minSizeBytes, err = humanize.ParseBytes(minSize)
+				if err != nil {
+					return err
+				}
+			}
+			filters := blockdevice.Filters{
+				Node:       node,
+				ClaimState: claimState,
+				State:      state,
+				MinSize:    minSizeBytes,
+			}
+			return blockdevice.Get(args, *openebsNS, outputFormat, filters)
+		},
+	}
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format. One of: table|json|yaml|wide")
+	cmd.Flags().StringVar(&node, "node", "", "Filter blockdevices by the node they're attached to")
+	cmd.Flags().StringVar(&claimState, "claim-state", "", "Filter blockdevices by claim state: Unclaimed|Claimed|Released")
+	cmd.Flags().StringVar(&state, "state", "", "Filter blockdevices by state: Active|Inactive|Unknown")
+	cmd.Flags().StringVar(&minSize, "min-size", "", "Filter blockdevices with at least this capacity, e.g. 100Gi")
+	return cmd
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2020-2021 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blockdevice
+
+import "testing"
+
+func TestMatchesOneOf(t *testing.T) {
+	valid := []string{"Unclaimed", "Claimed", "Released"}
+
+	tests := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{name: "empty value always matches", value: "", want: true},
+		{name: "exact match", value: "Claimed", want: true},
+		{name: "case-insensitive match", value: "claimed", want: true},
+		{name: "no match", value: "Bound", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesOneOf(tt.value, valid); got != tt.want {
+				t.Errorf("matchesOneOf(%q, %v) = %v, want %v", tt.value, valid, got, tt.want)
+			}
+		})
+	}
+}
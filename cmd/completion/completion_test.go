@@ -0,0 +1,73 @@
+/*
+Copyright 2020-2021 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package completion
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestRunCompletion(t *testing.T) {
+	tests := []struct {
+		name  string
+		shell string
+		want  bool // whether RunCompletion is expected to write anything to out
+	}{
+		{name: "bash", shell: "bash", want: true},
+		{name: "zsh", shell: "zsh", want: true},
+		{name: "fish", shell: "fish", want: true},
+		{name: "powershell", shell: "powershell", want: true},
+		{name: "unsupported shell", shell: "tcsh", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rootCmd := &cobra.Command{Use: "openebs"}
+			buf := new(bytes.Buffer)
+
+			RunCompletion(buf, rootCmd, []string{tt.shell})
+
+			if got := buf.Len() > 0; got != tt.want {
+				t.Errorf("RunCompletion(%q) wrote output = %v, want %v", tt.shell, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunCompletionNoDescriptions(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "openebs"}
+
+	noDescriptions = true
+	defer func() { noDescriptions = false }()
+
+	withDesc := new(bytes.Buffer)
+	noDescriptions = false
+	RunCompletionFish(withDesc, rootCmd)
+
+	withoutDesc := new(bytes.Buffer)
+	noDescriptions = true
+	RunCompletionFish(withoutDesc, rootCmd)
+
+	if withDesc.Len() == 0 || withoutDesc.Len() == 0 {
+		t.Fatal("expected fish completion output in both cases")
+	}
+	if withDesc.Len() == withoutDesc.Len() {
+		t.Errorf("expected --no-descriptions to change the generated fish completion output")
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2020-2021 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jiva
+
+import (
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/cmdutil"
+	"github.com/openebs/openebsctl/pkg/completion"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdGetJivaVolume lists Jiva Volumes in the openebs namespace, with
+// dynamic completion of Jiva Volume names from the cluster.
+func NewCmdGetJivaVolume(openebsNS *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "jivavolume [name ...]",
+		Aliases: []string{"jiva", "jivavolumes"},
+		Short:   "Displays Jiva Volume information",
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return completion.JivaVolumeNames(*openebsNS)(cmd, args, toComplete)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmdutil.RunList(*openebsNS, func(k *client.K8sClient) ([]string, error) {
+				jvList, err := k.GetJivaVolumes(args, "")
+				if err != nil {
+					return nil, err
+				}
+				names := make([]string, 0, len(jvList.Items))
+				for _, jv := range jvList.Items {
+					names = append(names, jv.Name)
+				}
+				return names, nil
+			})
+		},
+	}
+	return cmd
+}
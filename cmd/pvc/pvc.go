@@ -0,0 +1,51 @@
+/*
+Copyright 2020-2021 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pvc
+
+import (
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/cmdutil"
+	"github.com/openebs/openebsctl/pkg/completion"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdGetPVC lists PersistentVolumeClaims in the openebs namespace, with
+// dynamic completion of PersistentVolumeClaim names from the cluster.
+func NewCmdGetPVC(openebsNS *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "pvc [name ...]",
+		Aliases: []string{"pvcs"},
+		Short:   "Displays OpenEBS Persistent Volume Claims information",
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return completion.PersistentVolumeClaimNames(*openebsNS)(cmd, args, toComplete)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmdutil.RunList(*openebsNS, func(k *client.K8sClient) ([]string, error) {
+				pvcList, err := k.GetPVCs(*openebsNS, args, "")
+				if err != nil {
+					return nil, err
+				}
+				names := make([]string, 0, len(pvcList.Items))
+				for _, pvc := range pvcList.Items {
+					names = append(names, pvc.Name)
+				}
+				return names, nil
+			})
+		},
+	}
+	return cmd
+}
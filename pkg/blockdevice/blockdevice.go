@@ -17,6 +17,11 @@ limitations under the License.
 package blockdevice
 
 import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/dustin/go-humanize"
 	"github.com/openebs/api/v2/pkg/apis/openebs.io/v1alpha1"
 	"github.com/openebs/openebsctl/pkg/client"
@@ -24,6 +29,7 @@ import (
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/yaml"
 )
 
 const (
@@ -31,11 +37,89 @@ const (
 	lastElemPrefix  = `└─`
 )
 
+// ValidOutputFormats are the only values `-o/--output` accepts.
+var ValidOutputFormats = []string{"table", "json", "yaml", "wide"}
+
+// ValidClaimStates are the only values `--claim-state` accepts.
+var ValidClaimStates = []string{"Unclaimed", "Claimed", "Released"}
+
+// ValidStates are the only values `--state` accepts.
+var ValidStates = []string{"Active", "Inactive", "Unknown"}
+
+// bdTreeWideListColumnDefinations extends the default tree column set with
+// the extra fields shown by the `wide` output format. There's no upstream
+// util.BDTreeWideListColumnDefinations, so the base columns are reused as-is.
+var bdTreeWideListColumnDefinations = append(append([]metav1.TableColumnDefinition{},
+	util.BDTreeListColumnDefinations...),
+	metav1.TableColumnDefinition{Name: "DeviceType", Type: "string"},
+	metav1.TableColumnDefinition{Name: "Model", Type: "string"},
+	metav1.TableColumnDefinition{Name: "Serial", Type: "string"},
+)
+
+// sortedNodeNames returns the keys of nodeBDlistMap sorted alphabetically,
+// and sorts the blockdevices within each node by name, so table/json/yaml
+// output is stable across runs instead of depending on map iteration order.
+func sortedNodeNames(nodeBDlistMap map[string][]v1alpha1.BlockDevice) []string {
+	nodes := make([]string, 0, len(nodeBDlistMap))
+	for node, bds := range nodeBDlistMap {
+		nodes = append(nodes, node)
+		sort.Slice(bds, func(i, j int) bool { return bds[i].Name < bds[j].Name })
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// blockDeviceRow is the stable, machine-readable representation of a single
+// BlockDevice used by the json/yaml output modes.
+type blockDeviceRow struct {
+	Name          string `json:"name"`
+	Path          string `json:"path"`
+	Capacity      string `json:"capacity"`
+	CapacityBytes uint64 `json:"capacityBytes"`
+	ClaimState    string `json:"claimState"`
+	State         string `json:"state"`
+	FsType        string `json:"fsType"`
+	Mountpoint    string `json:"mountpoint"`
+}
+
+// nodeBlockDevices groups the blockDeviceRows belonging to a single node,
+// matching the node-by-node tree view.
+type nodeBlockDevices struct {
+	Node         string           `json:"node"`
+	BlockDevices []blockDeviceRow `json:"blockdevices"`
+}
+
+// Filters holds the predicates `blockdevice get` can apply before building
+// the node-by-node listing, e.g. --node, --claim-state, --state and --min-size.
+type Filters struct {
+	Node       string
+	ClaimState string
+	State      string
+	MinSize    uint64
+}
+
+// matches reports whether bd satisfies all the non-zero-value predicates in f.
+func (f Filters) matches(bd v1alpha1.BlockDevice) bool {
+	if f.Node != "" && bd.Spec.NodeAttributes.NodeName != f.Node {
+		return false
+	}
+	if f.ClaimState != "" && !strings.EqualFold(string(bd.Status.ClaimState), f.ClaimState) {
+		return false
+	}
+	if f.State != "" && !strings.EqualFold(string(bd.Status.State), f.State) {
+		return false
+	}
+	if f.MinSize != 0 && bd.Spec.Capacity.Storage < f.MinSize {
+		return false
+	}
+	return true
+}
+
 // Get manages various implementations of blockdevice listing
-func Get(bds []string, openebsNS string) error {
+func Get(bds []string, openebsNS string, outputFormat string, filters Filters) error {
 	// TODO: Prefer passing the client from outside
 	k, _ := client.NewK8sClient(openebsNS)
-	err := createTreeByNode(k, bds)
+	err := createTreeByNode(k, bds, outputFormat, filters)
 	if err != nil {
 		return err
 	}
@@ -44,16 +128,28 @@ func Get(bds []string, openebsNS string) error {
 
 // createTreeByNode uses the [node <- list of bds on the node] and creates a tree like output,
 // also showing the relevant details to the bds.
-func createTreeByNode(k *client.K8sClient, bds []string) error {
+func createTreeByNode(k *client.K8sClient, bds []string, outputFormat string, filters Filters) error {
+	switch outputFormat {
+	case "", "table", "json", "yaml", "wide":
+	default:
+		return errors.Errorf("unsupported output format %q, must be one of %v", outputFormat, ValidOutputFormats)
+	}
 	// 1. Get a list of the BlockDevices
 	var bdList *v1alpha1.BlockDeviceList
 	bdList, err := k.GetBDs(bds, "")
 	if err != nil {
 		return err
 	}
-	// 2. Create a map out of the list of bds, by their node names.
+	if len(bdList.Items) == 0 {
+		// If the namespace genuinely has no block devices at all
+		return errors.New("no blockdevices found in the " + k.Ns + " namespace")
+	}
+	// 2. Create a map out of the list of bds, by their node names, applying the filters.
 	var nodeBDlistMap = map[string][]v1alpha1.BlockDevice{}
 	for _, bd := range bdList.Items {
+		if !filters.matches(bd) {
+			continue
+		}
 		if _, ok := nodeBDlistMap[bd.Spec.NodeAttributes.NodeName]; ok {
 			// Append to the node if key exists
 			nodeBDlistMap[bd.Spec.NodeAttributes.NodeName] = append(nodeBDlistMap[bd.Spec.NodeAttributes.NodeName], bd)
@@ -62,48 +158,148 @@ func createTreeByNode(k *client.K8sClient, bds []string) error {
 			nodeBDlistMap[bd.Spec.NodeAttributes.NodeName] = []v1alpha1.BlockDevice{bd}
 		}
 	}
-	var rows []metav1.TableRow
 	if len(nodeBDlistMap) == 0 {
-		// If there are no block devices show error
-		return errors.New("no blockdevices found in the " + k.Ns + " namespace")
-	} else {
-		for key, value := range nodeBDlistMap {
-			// Create the root, which contains only the node-name
-			rows = append(rows, metav1.TableRow{Cells: []interface{}{key, "", "", "", "", "", ""}})
-			for i, bd := range value {
+		// There were block devices, but none of them matched the given filters
+		return errors.New("no blockdevices matched the given filters in the " + k.Ns + " namespace")
+	}
+	if outputFormat == "json" || outputFormat == "yaml" {
+		return writeBlockDevices(nodeBDlistMap, outputFormat)
+	}
+	if outputFormat == "wide" {
+		return writeWideTable(nodeBDlistMap)
+	}
+	var rows []metav1.TableRow
+	for _, key := range sortedNodeNames(nodeBDlistMap) {
+		value := nodeBDlistMap[key]
+		// Create the root, which contains only the node-name
+		rows = append(rows, metav1.TableRow{Cells: []interface{}{key, "", "", "", "", "", ""}})
+		for i, bd := range value {
+			// If the bd is the last bd in the list, or the list has only one bd
+			// append lastElementPrefix before bd name
+			if i == len(value)-1 {
+				rows = append(rows, metav1.TableRow{
+					Cells: []interface{}{
+						lastElemPrefix + bd.Name,
+						bd.Spec.Path,
+						humanize.IBytes(bd.Spec.Capacity.Storage),
+						bd.Status.ClaimState,
+						bd.Status.State,
+						bd.Spec.FileSystem.Type,
+						bd.Spec.FileSystem.Mountpoint,
+					}})
+			} else {
+				// If the bd is the not last bd in the list append firstElementPrefix before
+				// bd name which signifies there are more to append in the tree.
+				rows = append(rows, metav1.TableRow{
+					Cells: []interface{}{
+						firstElemPrefix + bd.Name,
+						bd.Spec.Path,
+						humanize.IBytes(bd.Spec.Capacity.Storage),
+						bd.Status.ClaimState,
+						bd.Status.State,
+						bd.Spec.FileSystem.Type,
+						bd.Spec.FileSystem.Mountpoint,
+					}})
+			}
+		}
+		// Add a summary row showing the count and total capacity of the shown bds on this node
+		var totalCapacity uint64
+		for _, bd := range value {
+			totalCapacity += bd.Spec.Capacity.Storage
+		}
+		rows = append(rows, metav1.TableRow{
+			Cells: []interface{}{
+				fmt.Sprintf("Total: %d", len(value)),
+				"", humanize.IBytes(totalCapacity), "", "", "", "",
+			}})
+		// Add an empty row so that the tree looks neat
+		rows = append(rows, metav1.TableRow{Cells: []interface{}{"", "", "", "", "", "", ""}})
+	}
+	// Show the output using cli-runtime
+	util.TablePrinter(util.BDTreeListColumnDefinations, rows, printers.PrintOptions{Wide: true})
+	return nil
+}
+
+// writeWideTable renders the same node-by-node tree as the default table
+// view, additionally showing the device type, model and serial of each bd.
+func writeWideTable(nodeBDlistMap map[string][]v1alpha1.BlockDevice) error {
+	var rows []metav1.TableRow
+	for _, key := range sortedNodeNames(nodeBDlistMap) {
+		value := nodeBDlistMap[key]
+		// Create the root, which contains only the node-name
+		rows = append(rows, metav1.TableRow{Cells: []interface{}{key, "", "", "", "", "", "", "", "", ""}})
+		for i, bd := range value {
+			prefix := firstElemPrefix
+			if i == len(value)-1 {
 				// If the bd is the last bd in the list, or the list has only one bd
 				// append lastElementPrefix before bd name
-				if i == len(value)-1 {
-					rows = append(rows, metav1.TableRow{
-						Cells: []interface{}{
-							lastElemPrefix + bd.Name,
-							bd.Spec.Path,
-							humanize.IBytes(bd.Spec.Capacity.Storage),
-							bd.Status.ClaimState,
-							bd.Status.State,
-							bd.Spec.FileSystem.Type,
-							bd.Spec.FileSystem.Mountpoint,
-						}})
-				} else {
-					// If the bd is the not last bd in the list append firstElementPrefix before
-					// bd name which signifies there are more to append in the tree.
-					rows = append(rows, metav1.TableRow{
-						Cells: []interface{}{
-							firstElemPrefix + bd.Name,
-							bd.Spec.Path,
-							humanize.IBytes(bd.Spec.Capacity.Storage),
-							bd.Status.ClaimState,
-							bd.Status.State,
-							bd.Spec.FileSystem.Type,
-							bd.Spec.FileSystem.Mountpoint,
-						}})
-				}
+				prefix = lastElemPrefix
 			}
-			// Add an empty row so that the tree looks neat
-			rows = append(rows, metav1.TableRow{Cells: []interface{}{"", "", "", "", "", "", ""}})
+			rows = append(rows, metav1.TableRow{
+				Cells: []interface{}{
+					prefix + bd.Name,
+					bd.Spec.Path,
+					humanize.IBytes(bd.Spec.Capacity.Storage),
+					bd.Status.ClaimState,
+					bd.Status.State,
+					bd.Spec.FileSystem.Type,
+					bd.Spec.FileSystem.Mountpoint,
+					bd.Spec.DeviceType,
+					bd.Spec.Details.Model,
+					bd.Spec.Details.Serial,
+				}})
+		}
+		// Add a summary row showing the count and total capacity of the shown bds on this node
+		var totalCapacity uint64
+		for _, bd := range value {
+			totalCapacity += bd.Spec.Capacity.Storage
 		}
+		rows = append(rows, metav1.TableRow{
+			Cells: []interface{}{
+				fmt.Sprintf("Total: %d", len(value)),
+				"", humanize.IBytes(totalCapacity), "", "", "", "", "", "", "",
+			}})
+		// Add an empty row so that the tree looks neat
+		rows = append(rows, metav1.TableRow{Cells: []interface{}{"", "", "", "", "", "", "", "", "", ""}})
 	}
 	// Show the output using cli-runtime
-	util.TablePrinter(util.BDTreeListColumnDefinations, rows, printers.PrintOptions{Wide: true})
+	util.TablePrinter(bdTreeWideListColumnDefinations, rows, printers.PrintOptions{Wide: true})
+	return nil
+}
+
+// writeBlockDevices marshals the node-grouped blockdevices to json or yaml
+// and prints it to stdout.
+func writeBlockDevices(nodeBDlistMap map[string][]v1alpha1.BlockDevice, outputFormat string) error {
+	var out []nodeBlockDevices
+	for _, node := range sortedNodeNames(nodeBDlistMap) {
+		bds := nodeBDlistMap[node]
+		rows := make([]blockDeviceRow, 0, len(bds))
+		for _, bd := range bds {
+			rows = append(rows, blockDeviceRow{
+				Name:          bd.Name,
+				Path:          bd.Spec.Path,
+				Capacity:      humanize.IBytes(bd.Spec.Capacity.Storage),
+				CapacityBytes: bd.Spec.Capacity.Storage,
+				ClaimState:    string(bd.Status.ClaimState),
+				State:         string(bd.Status.State),
+				FsType:        bd.Spec.FileSystem.Type,
+				Mountpoint:    bd.Spec.FileSystem.Mountpoint,
+			})
+		}
+		out = append(out, nodeBlockDevices{Node: node, BlockDevices: rows})
+	}
+	if outputFormat == "json" {
+		b, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+	b, err := yaml.Marshal(out)
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(b))
 	return nil
 }
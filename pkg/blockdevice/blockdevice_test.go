@@ -0,0 +1,155 @@
+/*
+Copyright 2020-2021 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blockdevice
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/openebs/api/v2/pkg/apis/openebs.io/v1alpha1"
+	"sigs.k8s.io/yaml"
+)
+
+// captureStdout runs fn with os.Stdout redirected to a pipe and returns
+// everything written to it.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() failed: %v", err)
+	}
+	orig := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = orig }()
+
+	fn()
+
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("reading captured stdout failed: %v", err)
+	}
+	return string(out)
+}
+
+func testBD(node string, claimState v1alpha1.BlockDeviceClaimState, state v1alpha1.BlockDeviceState, capacity uint64) v1alpha1.BlockDevice {
+	bd := v1alpha1.BlockDevice{}
+	bd.Spec.NodeAttributes.NodeName = node
+	bd.Spec.Capacity.Storage = capacity
+	bd.Status.ClaimState = claimState
+	bd.Status.State = state
+	return bd
+}
+
+func TestFiltersMatches(t *testing.T) {
+	bd := testBD("node-1", "Unclaimed", "Active", 100*1024*1024*1024)
+
+	tests := []struct {
+		name    string
+		filters Filters
+		want    bool
+	}{
+		{name: "no filters matches everything", filters: Filters{}, want: true},
+		{name: "matching node", filters: Filters{Node: "node-1"}, want: true},
+		{name: "non-matching node", filters: Filters{Node: "node-2"}, want: false},
+		{name: "matching claim-state", filters: Filters{ClaimState: "Unclaimed"}, want: true},
+		{name: "matching claim-state case-insensitive", filters: Filters{ClaimState: "unclaimed"}, want: true},
+		{name: "non-matching claim-state", filters: Filters{ClaimState: "Claimed"}, want: false},
+		{name: "matching state", filters: Filters{State: "Active"}, want: true},
+		{name: "matching state case-insensitive", filters: Filters{State: "active"}, want: true},
+		{name: "non-matching state", filters: Filters{State: "Inactive"}, want: false},
+		{name: "min-size below capacity", filters: Filters{MinSize: 50 * 1024 * 1024 * 1024}, want: true},
+		{name: "min-size equal to capacity", filters: Filters{MinSize: 100 * 1024 * 1024 * 1024}, want: true},
+		{name: "min-size above capacity", filters: Filters{MinSize: 200 * 1024 * 1024 * 1024}, want: false},
+		{
+			name:    "all filters matching combined",
+			filters: Filters{Node: "node-1", ClaimState: "Unclaimed", State: "Active", MinSize: 50 * 1024 * 1024 * 1024},
+			want:    true,
+		},
+		{
+			name:    "one non-matching filter fails the combination",
+			filters: Filters{Node: "node-1", ClaimState: "Claimed", State: "Active", MinSize: 50 * 1024 * 1024 * 1024},
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filters.matches(bd); got != tt.want {
+				t.Errorf("Filters%+v.matches(bd) = %v, want %v", tt.filters, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteBlockDevices(t *testing.T) {
+	bd := testBD("node-1", "Unclaimed", "Active", 100*1024*1024*1024)
+	bd.Name = "bd-1"
+	bd.Spec.Path = "/dev/sdb"
+	nodeBDlistMap := map[string][]v1alpha1.BlockDevice{"node-1": {bd}}
+
+	tests := []struct {
+		name         string
+		outputFormat string
+	}{
+		{name: "json", outputFormat: "json"},
+		{name: "yaml", outputFormat: "yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := captureStdout(t, func() {
+				if err := writeBlockDevices(nodeBDlistMap, tt.outputFormat); err != nil {
+					t.Fatalf("writeBlockDevices() error = %v", err)
+				}
+			})
+
+			// sigs.k8s.io/yaml.Unmarshal accepts JSON too, since JSON is a
+			// YAML subset, so both formats round-trip through the same call.
+			var got []nodeBlockDevices
+			if err := yaml.Unmarshal([]byte(out), &got); err != nil {
+				t.Fatalf("output is not valid %s: %v\noutput: %s", tt.outputFormat, err, out)
+			}
+
+			if len(got) != 1 || got[0].Node != "node-1" {
+				t.Fatalf("unexpected node grouping: %+v", got)
+			}
+			if len(got[0].BlockDevices) != 1 {
+				t.Fatalf("expected 1 blockdevice, got %d", len(got[0].BlockDevices))
+			}
+			row := got[0].BlockDevices[0]
+			if row.Name != "bd-1" || row.Path != "/dev/sdb" || row.ClaimState != "Unclaimed" || row.State != "Active" {
+				t.Errorf("unexpected blockDeviceRow: %+v", row)
+			}
+			if row.CapacityBytes != 100*1024*1024*1024 {
+				t.Errorf("CapacityBytes = %d, want %d", row.CapacityBytes, 100*1024*1024*1024)
+			}
+		})
+	}
+}
+
+func TestCreateTreeByNodeUnsupportedOutputFormat(t *testing.T) {
+	err := createTreeByNode(nil, nil, "xml", Filters{})
+	if err == nil {
+		t.Fatal("expected an error for an unsupported output format, got nil")
+	}
+	if !strings.Contains(err.Error(), "unsupported output format") {
+		t.Errorf("error = %q, want it to mention an unsupported output format", err.Error())
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2020-2021 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cmdutil holds small helpers shared across the cmd/* packages to
+// avoid repeating the same cobra RunE boilerplate in each of them.
+package cmdutil
+
+import (
+	"fmt"
+
+	"github.com/openebs/openebsctl/pkg/client"
+)
+
+// RunList constructs a K8sClient for openebsNS, runs lister against it and
+// prints each of the resulting names on its own line. It is the shared body
+// behind the `RunE` of the cstor, jiva, pvc and volume `get <resource> [name ...]`
+// commands; `get blockdevice` prints its own tree/json/yaml output instead.
+func RunList(openebsNS string, lister func(*client.K8sClient) ([]string, error)) error {
+	k, err := client.NewK8sClient(openebsNS)
+	if err != nil {
+		return err
+	}
+	names, err := lister(k)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		fmt.Println(name)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2020-2021 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package completion holds the dynamic, cluster-aware ValidArgsFunction
+// helpers that cobra invokes through its hidden `__complete` subcommand,
+// e.g. `kubectl openebs describe volume <TAB>`.
+package completion
+
+import (
+	"context"
+	"time"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// completionTimeout bounds how long a single TAB-completion lookup is
+// allowed to block the user's shell on the Kubernetes API, so a slow or
+// unreachable cluster doesn't hang mid-keystroke. Note that this only
+// bounds the *wait*: client.NewK8sClient and the lister neither accept nor
+// honour a context today, so the underlying goroutine and its API call run
+// to completion (or hang) in the background even after we give up on it.
+const completionTimeout = 2 * time.Second
+
+// listNames constructs a K8sClient for openebsNS and runs lister against it,
+// returning the resulting names. It is the shared body behind every
+// ValidArgsFunction in this package.
+func listNames(openebsNS string, lister func(*client.K8sClient) ([]string, error)) ([]string, cobra.ShellCompDirective) {
+	return awaitNames(completionTimeout, func() ([]string, error) {
+		k, err := client.NewK8sClient(openebsNS)
+		if err != nil {
+			return nil, err
+		}
+		return lister(k)
+	})
+}
+
+// awaitNames runs fn in a goroutine and waits up to timeout for it to
+// finish, returning no completions if the deadline passes first. fn is not
+// cancelled when the deadline passes -- see the completionTimeout doc
+// comment -- so this only bounds how long the caller waits, not fn itself.
+func awaitNames(timeout time.Duration, fn func() ([]string, error)) ([]string, cobra.ShellCompDirective) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	type result struct {
+		names []string
+		err   error
+	}
+	resCh := make(chan result, 1)
+	go func() {
+		names, err := fn()
+		resCh <- result{names: names, err: err}
+	}()
+
+	select {
+	case res := <-resCh:
+		if res.err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return res.names, cobra.ShellCompDirectiveNoFileComp
+	case <-ctx.Done():
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// BlockDeviceNames is a cobra.Command ValidArgsFunction that completes
+// BlockDevice names by querying the openebsNS namespace in the cluster.
+// It is meant to be registered on commands such as
+// `kubectl openebs describe blockdevice` and `kubectl openebs get blockdevice`.
+func BlockDeviceNames(openebsNS string) func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return listNames(openebsNS, func(k *client.K8sClient) ([]string, error) {
+			bdList, err := k.GetBDs(nil, "")
+			if err != nil {
+				return nil, err
+			}
+			names := make([]string, 0, len(bdList.Items))
+			for _, bd := range bdList.Items {
+				names = append(names, bd.Name)
+			}
+			return names, nil
+		})
+	}
+}
+
+// PersistentVolumeNames is a cobra.Command ValidArgsFunction that completes
+// PersistentVolume names, for commands such as
+// `kubectl openebs describe volume` and `kubectl openebs get volume`.
+func PersistentVolumeNames(openebsNS string) func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return listNames(openebsNS, func(k *client.K8sClient) ([]string, error) {
+			pvList, err := k.GetPVs(nil)
+			if err != nil {
+				return nil, err
+			}
+			names := make([]string, 0, len(pvList.Items))
+			for _, pv := range pvList.Items {
+				names = append(names, pv.Name)
+			}
+			return names, nil
+		})
+	}
+}
+
+// PersistentVolumeClaimNames is a cobra.Command ValidArgsFunction that
+// completes PersistentVolumeClaim names in the openebsNS namespace.
+func PersistentVolumeClaimNames(openebsNS string) func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return listNames(openebsNS, func(k *client.K8sClient) ([]string, error) {
+			pvcList, err := k.GetPVCs(openebsNS, nil, "")
+			if err != nil {
+				return nil, err
+			}
+			names := make([]string, 0, len(pvcList.Items))
+			for _, pvc := range pvcList.Items {
+				names = append(names, pvc.Name)
+			}
+			return names, nil
+		})
+	}
+}
+
+// CSPINames is a cobra.Command ValidArgsFunction that completes cStor Pool
+// Instance names in the openebsNS namespace, for cstor commands.
+func CSPINames(openebsNS string) func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return listNames(openebsNS, func(k *client.K8sClient) ([]string, error) {
+			cspiList, err := k.GetCSPI(nil, "")
+			if err != nil {
+				return nil, err
+			}
+			names := make([]string, 0, len(cspiList.Items))
+			for _, cspi := range cspiList.Items {
+				names = append(names, cspi.Name)
+			}
+			return names, nil
+		})
+	}
+}
+
+// JivaVolumeNames is a cobra.Command ValidArgsFunction that completes Jiva
+// Volume names in the openebsNS namespace, for jiva commands.
+func JivaVolumeNames(openebsNS string) func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return listNames(openebsNS, func(k *client.K8sClient) ([]string, error) {
+			jvList, err := k.GetJivaVolumes(nil, "")
+			if err != nil {
+				return nil, err
+			}
+			names := make([]string, 0, len(jvList.Items))
+			for _, jv := range jvList.Items {
+				names = append(names, jv.Name)
+			}
+			return names, nil
+		})
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2020-2021 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package completion
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func TestAwaitNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		fn      func() ([]string, error)
+		want    []string
+		wantDir cobra.ShellCompDirective
+	}{
+		{
+			name:    "fast success returns the names",
+			fn:      func() ([]string, error) { return []string{"bd-1", "bd-2"}, nil },
+			want:    []string{"bd-1", "bd-2"},
+			wantDir: cobra.ShellCompDirectiveNoFileComp,
+		},
+		{
+			name:    "lister error yields no completions",
+			fn:      func() ([]string, error) { return nil, errors.New("boom") },
+			want:    nil,
+			wantDir: cobra.ShellCompDirectiveNoFileComp,
+		},
+		{
+			name: "slow lister times out instead of blocking the caller",
+			fn: func() ([]string, error) {
+				time.Sleep(time.Second)
+				return []string{"bd-1"}, nil
+			},
+			want:    nil,
+			wantDir: cobra.ShellCompDirectiveNoFileComp,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			got, gotDir := awaitNames(20*time.Millisecond, tt.fn)
+			if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+				t.Errorf("awaitNames blocked for %s, want it bounded by the timeout", elapsed)
+			}
+			if gotDir != tt.wantDir {
+				t.Errorf("awaitNames() directive = %v, want %v", gotDir, tt.wantDir)
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("awaitNames() names = %v, want %v", got, tt.want)
+				return
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("awaitNames() names = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}